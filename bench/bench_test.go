@@ -0,0 +1,60 @@
+package bench
+
+import (
+	"fmt"
+	"testing"
+)
+
+var solvers = map[string]Solver{
+	"backtrack": BacktrackSolver{},
+	"dlx":       DLXSolver{},
+}
+
+// BenchmarkSolve measures solve time, nodes visited, backtracks, and
+// allocations per op (count and total bytes, via ReportAllocs; peak memory
+// use is not measured) for every solver backend against every puzzle in
+// the corpus, e.g.:
+//
+//	go test ./bench -bench BenchmarkSolve -benchmem
+func BenchmarkSolve(b *testing.B) {
+	for _, p := range Corpus {
+		puzzle := p.Parse()
+		for name, solver := range solvers {
+			b.Run(fmt.Sprintf("%s/%s", p.Name, name), func(b *testing.B) {
+				b.ReportAllocs()
+				var stats Stats
+				for i := 0; i < b.N; i++ {
+					_, s, err := solver.Solve(puzzle)
+					if err != nil {
+						b.Fatalf("%s could not solve %s: %v", name, p.Name, err)
+					}
+					stats = s
+				}
+				b.ReportMetric(float64(stats.Nodes), "nodes/op")
+				b.ReportMetric(float64(stats.Backtracks), "backtracks/op")
+			})
+		}
+	}
+}
+
+// TestSolversAgree checks that every backend finds a valid solution for
+// every puzzle in the corpus.
+func TestSolversAgree(t *testing.T) {
+	for _, p := range Corpus {
+		puzzle := p.Parse()
+		for name, solver := range solvers {
+			solution, _, err := solver.Solve(puzzle)
+			if err != nil {
+				t.Fatalf("%s could not solve %s: %v", name, p.Name, err)
+			}
+			if rejects(solution) {
+				t.Fatalf("%s produced an invalid solution for %s", name, p.Name)
+			}
+			for i, clue := range puzzle {
+				if clue != 0 && solution[i] != clue {
+					t.Fatalf("%s changed clue at cell %d solving %s", name, i, p.Name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Command sudoku-bench runs every solver backend from the bench package
+// against the benchmark corpus and prints a comparison table, for
+// reproducible performance regression tracking outside of `go test
+// -bench`.
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/BeaconBrigade/go-sudoku/bench"
+)
+
+func main() {
+	solvers := []struct {
+		name   string
+		solver bench.Solver
+	}{
+		{"backtrack", bench.BacktrackSolver{}},
+		{"dlx", bench.DLXSolver{}},
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PUZZLE\tDIFFICULTY\tSOLVER\tDURATION\tNODES\tBACKTRACKS")
+
+	for _, p := range bench.Corpus {
+		puzzle := p.Parse()
+		for _, s := range solvers {
+			_, stats, err := s.solver.Solve(puzzle)
+			if err != nil {
+				fmt.Fprintf(w, "%s\t%s\t%s\tERROR: %v\t-\t-\n", p.Name, p.Difficulty, s.name, err)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%dns\t%d\t%d\n",
+				p.Name, p.Difficulty, s.name, stats.DurationNs, stats.Nodes, stats.Backtracks)
+		}
+	}
+
+	w.Flush()
+}
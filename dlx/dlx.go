@@ -0,0 +1,262 @@
+// Package dlx solves Sudoku as an exact cover problem using Knuth's
+// Algorithm X with dancing links (DLX). It models the puzzle as a
+// 324x729 constraint matrix (81 cell + 81 row + 81 column + 81 box
+// constraints, each with 9 candidate digits) and searches it with a
+// toroidal doubly-linked mesh of nodes, always covering the column with
+// the fewest remaining rows first.
+package dlx
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	cellConstraints = 81
+	rowConstraints  = 81
+	colConstraints  = 81
+	boxConstraints  = 81
+	numColumns      = cellConstraints + rowConstraints + colConstraints + boxConstraints
+	numDigits       = 9
+)
+
+// Node is one element of the toroidal doubly-linked mesh. Header nodes
+// (the columns themselves) are Nodes too, with Column pointing back at
+// their own ColumnHeader.
+type Node struct {
+	Left, Right, Up, Down *Node
+	Column                *ColumnHeader
+	// Row identifies which (cell, digit) choice this node's row
+	// represents, encoded as cell*9 + (digit-1). Unused on header nodes.
+	Row int
+}
+
+// ColumnHeader is the header node for one constraint column.
+type ColumnHeader struct {
+	Node
+	Name string
+	Size int
+}
+
+// Matrix is the exact cover matrix for a single puzzle.
+type Matrix struct {
+	root    ColumnHeader
+	columns []*ColumnHeader
+}
+
+// NewMatrix builds the exact cover matrix for puzzle, omitting rows that
+// conflict with prefilled clues.
+func NewMatrix(puzzle [81]uint8) *Matrix {
+	m := &Matrix{}
+	m.root.Column = &m.root
+	m.root.Left = &m.root.Node
+	m.root.Right = &m.root.Node
+	m.root.Row = -1
+
+	m.columns = make([]*ColumnHeader, numColumns)
+	for i := range m.columns {
+		h := &ColumnHeader{Name: columnName(i)}
+		h.Column = h
+		h.Row = -1
+		h.Up = &h.Node
+		h.Down = &h.Node
+
+		h.Left = m.root.Left
+		h.Right = &m.root.Node
+		m.root.Left.Right = &h.Node
+		m.root.Left = &h.Node
+
+		m.columns[i] = h
+	}
+
+	for cell := 0; cell < 81; cell++ {
+		row, col := cell/9, cell%9
+		box := (row/3)*3 + col/3
+		clue := puzzle[cell]
+
+		for digit := 1; digit <= numDigits; digit++ {
+			if clue != 0 && int(clue) != digit {
+				continue
+			}
+
+			m.addRow(cell*9+(digit-1), [4]int{
+				cell,
+				cellConstraints + row*9 + (digit - 1),
+				cellConstraints + rowConstraints + col*9 + (digit - 1),
+				cellConstraints + rowConstraints + colConstraints + box*9 + (digit - 1),
+			})
+		}
+	}
+
+	return m
+}
+
+func (m *Matrix) addRow(rowID int, columns [4]int) {
+	var first *Node
+	for _, ci := range columns {
+		h := m.columns[ci]
+		n := &Node{Column: h, Row: rowID}
+
+		n.Up = h.Up
+		n.Down = &h.Node
+		h.Up.Down = n
+		h.Up = n
+		h.Size++
+
+		if first == nil {
+			first = n
+			n.Left = n
+			n.Right = n
+		} else {
+			n.Left = first.Left
+			n.Right = first
+			first.Left.Right = n
+			first.Left = n
+		}
+	}
+}
+
+func (m *Matrix) cover(col *ColumnHeader) {
+	col.Right.Left = col.Left
+	col.Left.Right = col.Right
+
+	for row := col.Down; row != &col.Node; row = row.Down {
+		for node := row.Right; node != row; node = node.Right {
+			node.Down.Up = node.Up
+			node.Up.Down = node.Down
+			node.Column.Size--
+		}
+	}
+}
+
+func (m *Matrix) uncover(col *ColumnHeader) {
+	for row := col.Up; row != &col.Node; row = row.Up {
+		for node := row.Left; node != row; node = node.Left {
+			node.Column.Size++
+			node.Down.Up = node
+			node.Up.Down = node
+		}
+	}
+
+	col.Left.Right = &col.Node
+	col.Right.Left = &col.Node
+}
+
+// chooseColumn picks the live column with the fewest rows (the "S"
+// heuristic), which keeps the search tree as narrow as possible.
+func (m *Matrix) chooseColumn() *ColumnHeader {
+	best := m.root.Right.Column
+	for node := m.root.Right.Right; node != &m.root.Node; node = node.Right {
+		if node.Column.Size < best.Size {
+			best = node.Column
+		}
+	}
+	return best
+}
+
+// Stats reports how much search a Solve call took, for benchmarking.
+type Stats struct {
+	Nodes      int
+	Backtracks int
+}
+
+// search performs the recursive Algorithm X search. onSolution is called
+// with the chosen row IDs each time the matrix is fully covered; it
+// should return true to stop the search early. The search also stops,
+// unwinding without calling onSolution again, once ctx is done. stats may
+// be nil.
+func (m *Matrix) search(ctx context.Context, solution []int, onSolution func([]int) bool, stats *Stats) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	if stats != nil {
+		stats.Nodes++
+	}
+	if m.root.Right == &m.root.Node {
+		return onSolution(solution)
+	}
+
+	col := m.chooseColumn()
+	m.cover(col)
+
+	stop := false
+	for row := col.Down; row != &col.Node && !stop; row = row.Down {
+		solution = append(solution, row.Row)
+		for node := row.Right; node != row; node = node.Right {
+			m.cover(node.Column)
+		}
+
+		stop = m.search(ctx, solution, onSolution, stats)
+		if !stop && stats != nil {
+			stats.Backtracks++
+		}
+
+		solution = solution[:len(solution)-1]
+		for node := row.Left; node != row; node = node.Left {
+			m.uncover(node.Column)
+		}
+	}
+
+	m.uncover(col)
+	return stop
+}
+
+// Solve finds a single solution to puzzle and returns the same [81]uint8
+// grid the backtracking solver produces. Solving stops and returns
+// ctx.Err() if ctx is cancelled first.
+func Solve(ctx context.Context, puzzle [81]uint8) ([81]uint8, error) {
+	result, _, err := SolveWithStats(ctx, puzzle)
+	return result, err
+}
+
+// SolveWithStats behaves like Solve but also reports how much search it
+// took, for comparing against other solver backends.
+func SolveWithStats(ctx context.Context, puzzle [81]uint8) ([81]uint8, Stats, error) {
+	m := NewMatrix(puzzle)
+
+	var result [81]uint8
+	var stats Stats
+	found := false
+	m.search(ctx, nil, func(solution []int) bool {
+		found = true
+		for _, rowID := range solution {
+			result[rowID/numDigits] = uint8(rowID%numDigits) + 1
+		}
+		return true
+	}, &stats)
+
+	if err := ctx.Err(); err != nil {
+		return result, stats, err
+	}
+	if !found {
+		return result, stats, errors.New("no solution")
+	}
+	return result, stats, nil
+}
+
+// CountSolutions counts up to limit distinct solutions to puzzle. A limit
+// of 0 counts every solution, which can be slow on puzzles with many.
+func CountSolutions(ctx context.Context, puzzle [81]uint8, limit int) int {
+	m := NewMatrix(puzzle)
+
+	count := 0
+	m.search(ctx, nil, func(solution []int) bool {
+		count++
+		return limit > 0 && count >= limit
+	}, nil)
+
+	return count
+}
+
+func columnName(i int) string {
+	switch {
+	case i < cellConstraints:
+		return "cell"
+	case i < cellConstraints+rowConstraints:
+		return "row"
+	case i < cellConstraints+rowConstraints+colConstraints:
+		return "col"
+	default:
+		return "box"
+	}
+}
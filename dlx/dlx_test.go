@@ -0,0 +1,66 @@
+package dlx
+
+import (
+	"context"
+	"testing"
+)
+
+// solvablePuzzle has a unique solution.
+const solvablePuzzle = "083007060910002740207100000190000030652800070004000500501046007840709300729000006"
+
+func parsePuzzle(s string) [81]uint8 {
+	var grid [81]uint8
+	for i := 0; i < 81 && i < len(s); i++ {
+		grid[i] = s[i] - '0'
+	}
+	return grid
+}
+
+// TestSolveUnsolvable checks that a puzzle with a direct constraint
+// violation (two 8s in row 0) reports no solution instead of panicking or
+// returning a bogus grid.
+func TestSolveUnsolvable(t *testing.T) {
+	puzzle := parsePuzzle("880000000000000000000000000000000000000000000000000000000000000000000000000000")
+
+	_, err := Solve(context.Background(), puzzle)
+	if err == nil {
+		t.Fatal("Solve returned no error for an unsolvable puzzle")
+	}
+}
+
+// TestCountSolutionsRespectsLimit checks that CountSolutions stops at
+// limit instead of exhaustively enumerating every solution, using a
+// puzzle sparse enough to have far more than a handful of solutions.
+func TestCountSolutionsRespectsLimit(t *testing.T) {
+	var empty [81]uint8
+
+	for _, limit := range []int{1, 2, 5} {
+		if got := CountSolutions(context.Background(), empty, limit); got != limit {
+			t.Errorf("CountSolutions(limit=%d) = %d, want %d", limit, got, limit)
+		}
+	}
+}
+
+// TestCountSolutionsUnique checks the limit=0 (count everything) path
+// against a puzzle known to have exactly one solution.
+func TestCountSolutionsUnique(t *testing.T) {
+	puzzle := parsePuzzle(solvablePuzzle)
+	if got := CountSolutions(context.Background(), puzzle, 0); got != 1 {
+		t.Errorf("CountSolutions(limit=0) = %d, want 1", got)
+	}
+}
+
+// TestSolveContextCancellation checks that an already-cancelled context
+// stops search immediately, exercising the ctx.Err() check at the top of
+// Matrix.search, rather than running the solve to completion regardless.
+func TestSolveContextCancellation(t *testing.T) {
+	puzzle := parsePuzzle(solvablePuzzle)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Solve(ctx, puzzle)
+	if err != context.Canceled {
+		t.Fatalf("Solve with a cancelled context returned err=%v, want context.Canceled", err)
+	}
+}
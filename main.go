@@ -1,23 +1,50 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"runtime"
+	"sync"
 	"time"
 	"unicode"
+
+	"github.com/BeaconBrigade/go-sudoku/dlx"
+	"github.com/BeaconBrigade/go-sudoku/trace"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "analyze" {
+		analyze(os.Args[2:])
+		return
+	}
+
 	input := flag.String("input", "", "Location of puzzle to read or stdin by default")
 	output := flag.String("output", "", "Output location for solution, or stdout by default")
 	printPartials := flag.Bool("print-partials", false, "Print each partial puzzle to stdout")
 	delay := flag.Int("delay", 0, "Add delay between each iteration (useful with `--print-partials`)")
+	parallel := flag.Int("parallel", 1, "Number of workers to search with concurrently (0 uses GOMAXPROCS, 1 disables parallel search)")
+	solver := flag.String("solver", "backtrack", "Solver backend to use: backtrack or dlx")
+	count := flag.Bool("count", false, "Count the number of solutions instead of printing one, to detect non-unique puzzles")
+	tracePath := flag.String("trace", "", "Record a timeline of solver events to this file, readable with `go-sudoku analyze`")
+	serveAddr := flag.String("serve", "", "Start an HTTP server on this address instead of solving stdin/file input, e.g. :8080")
+	maxConcurrentSolves := flag.Int("max-concurrent-solves", 8, "Maximum number of solves the HTTP server will run at once")
+	plainProgress := flag.Bool("plain-progress", false, "With --print-partials, always print a one-line progress summary instead of redrawing the board (default when output isn't a terminal)")
+	progressEvery := flag.Int("progress-every", 1, "With --print-partials in plain mode, print a summary only every N steps")
 
 	flag.Parse()
 
+	if *serveAddr != "" {
+		if err := serve(*serveAddr, *solver, *maxConcurrentSolves); err != nil {
+			fmt.Println("Server error: ", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// get input
 	var inFile []byte
 	if *input == "" {
@@ -54,69 +81,247 @@ func main() {
 		fmt.Println("Could not parse input: ", err)
 		os.Exit(1)
 	}
-	root := Node{
-		Candidate:  candidate,
-		MostRecent: 0,
-		Children:   [9]*Node{nil},
+	config := Config{Input: inFile, Output: outFile, PrintPartials: *printPartials, Delay: *delay, Parallel: *parallel}
+
+	if *printPartials {
+		tty := isTerminalWriter(outFile) && !*plainProgress
+		config.Progress = newProgressPrinter(outFile, tty, *progressEvery)
+	}
+
+	if *tracePath != "" {
+		f, err := os.Create(*tracePath)
+		if err != nil {
+			fmt.Println("Could not open trace file: ", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		config.Trace = trace.New(f)
 	}
-	config := Config{Input: inFile, Output: outFile, PrintPartials: *printPartials, Delay: *delay}
 
-	solution := root.Backtrack(&config)
-	if solution == nil {
-		fmt.Println("Could not solve puzzle")
+	if *count {
+		n := dlx.CountSolutions(context.Background(), candidate, 0)
+		fmt.Printf("%d solution(s)\n", n)
+		return
+	}
+
+	var result Node
+	switch *solver {
+	case "dlx":
+		grid, err := dlx.Solve(context.Background(), candidate)
+		if err != nil {
+			fmt.Println("Could not solve puzzle")
+			os.Exit(1)
+		}
+		result = Node{Candidate: grid}
+	case "backtrack":
+		root := Node{Candidate: candidate}
+		solution := root.BacktrackParallel(&config)
+		if solution == nil {
+			fmt.Println("Could not solve puzzle")
+			os.Exit(1)
+		}
+		result = *solution
+	default:
+		fmt.Println("Unknown solver: ", *solver)
 		os.Exit(1)
 	}
 
-	solution.PrintSolution(&config)
+	result.PrintSolution(&config)
+}
+
+// analyze implements the `go-sudoku analyze <trace>` subcommand, printing
+// summary statistics for a trace recorded with -trace.
+func analyze(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Println("usage: go-sudoku analyze <trace-file>")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Println("Could not open trace: ", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	stats, err := trace.Analyze(f)
+	if err != nil {
+		fmt.Println("Could not analyze trace: ", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("nodes explored:     %d\n", stats.Nodes)
+	fmt.Printf("backtracks:         %d\n", stats.Backtracks)
+	fmt.Printf("accepts:            %d\n", stats.Accepts)
+	fmt.Printf("avg branching:      %.2f\n", stats.AvgBranching)
+	fmt.Println("time per depth (ns):")
+	for depth := 0; depth < len(stats.TimePerDepth); depth++ {
+		if ns, ok := stats.TimePerDepth[depth]; ok {
+			fmt.Printf("  depth %3d: %d\n", depth, ns)
+		}
+	}
+	fmt.Println("utilization (forward progress per window):")
+	for i, u := range stats.Utilization {
+		fmt.Printf("  window %3d: %.1f%%\n", i, u*100)
+	}
 }
 
 type Config struct {
 	Input         []byte
-	Output        *os.File
+	Output        io.Writer
 	PrintPartials bool
 	Delay         int
+	// Parallel is the number of workers to fan the search tree out across.
+	// 0 means use GOMAXPROCS, 1 means search sequentially.
+	Parallel int
+	// Trace records solver events for later analysis, or nil to disable
+	// tracing.
+	Trace *trace.Writer
+	// Progress renders -print-partials output, or nil to disable it.
+	Progress *progressPrinter
 }
 
 type Node struct {
-	Candidate  [81]uint8
-	MostRecent uint8
-	Children   [9]*Node
+	Candidate [81]uint8
 }
 
+// Backtrack searches for a solution sequentially, depth-first.
 func (n *Node) Backtrack(config *Config) *Node {
+	return n.backtrack(context.Background(), config, -1, 0)
+}
+
+// backtrack does the actual search. cell and digit describe the guess that
+// produced n, or cell < 0 for the root call.
+func (n *Node) backtrack(ctx context.Context, config *Config, cell int, digit uint8) *Node {
+	if ctx.Err() != nil {
+		return nil
+	}
 	if config.Delay != 0 {
 		time.Sleep(time.Second * time.Duration(config.Delay))
 	}
 	if config.PrintPartials {
-		n.PrintSolution(config)
+		config.Progress.Print(n, cell, digit)
 	}
 
 	if n.Reject() {
+		config.Trace.Record(trace.EventReject, cell, digit)
 		return nil
 	} else if n.Accept() {
+		config.Trace.Record(trace.EventAccept, cell, digit)
 		return n
 	}
 
-	toChange := n.First()
-	next := n.Children[n.MostRecent]
-
-	for next != nil {
-		solution := next.Backtrack(config)
-		if solution != nil {
+	child, toChange := n.First()
+	config.Trace.Record(trace.EventAssign, int(toChange), child.Candidate[toChange])
+	for {
+		if solution := child.backtrack(ctx, config, int(toChange), child.Candidate[toChange]); solution != nil {
 			return solution
 		}
+		config.Trace.Record(trace.EventBacktrack, int(toChange), child.Candidate[toChange])
 
-		if n.Next(toChange) {
-			next = n.Children[n.MostRecent]
-		} else {
+		next, ok := n.Next(child, toChange)
+		if !ok {
 			break
 		}
+		child = next
+		config.Trace.Record(trace.EventAssign, int(toChange), child.Candidate[toChange])
 	}
 	return nil
 }
 
-func (n *Node) First() uint8 {
-	child := copyCandidate(n)
+// BacktrackParallel behaves like Backtrack, but fans the root level of the
+// search tree out across a fixed pool of goroutines. The pool size comes
+// from config.Parallel (0 means GOMAXPROCS, 1 disables parallelism and falls
+// back to Backtrack). The first goroutine to reach Accept cancels the rest.
+func (n *Node) BacktrackParallel(config *Config) *Node {
+	workers := config.Parallel
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers <= 1 {
+		return n.Backtrack(config)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var result *Node
+
+	accept := func(node *Node) {
+		once.Do(func() {
+			result = node
+			cancel()
+		})
+	}
+
+	// Fan out exactly one level: the root dispatches each of its
+	// children as a goroutine bounded by sem, and every one of those
+	// goroutines solves its subtree sequentially from there. Fanning out
+	// a second level would mean a goroutine that's already holding a sem
+	// slot (acquired when it was dispatched) blocking to acquire further
+	// slots from the same pool to dispatch its own children — once the
+	// pool fills with such dispatchers, they deadlock waiting on slots
+	// that can only be freed by a leaf finishing, which starves the pool
+	// down to roughly one concurrent leaf. Capping at one level avoids
+	// that: only the un-dispatched root ever fans out.
+	const depthLimit = 1
+
+	var explore func(node *Node, depth, cell int, digit uint8)
+	explore = func(node *Node, depth, cell int, digit uint8) {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			return
+		}
+
+		if depth >= depthLimit {
+			if solution := node.backtrack(ctx, config, cell, digit); solution != nil {
+				accept(solution)
+			}
+			return
+		}
+
+		if node.Reject() {
+			return
+		} else if node.Accept() {
+			accept(node)
+			return
+		}
+
+		child, toChange := node.First()
+		for {
+			c := child
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() { <-sem }()
+				explore(&c, depth+1, int(toChange), c.Candidate[toChange])
+			}()
+
+			next, ok := node.Next(child, toChange)
+			if !ok {
+				break
+			}
+			child = next
+		}
+	}
+
+	wg.Add(1)
+	explore(n, 0, -1, 0)
+	wg.Wait()
+
+	return result
+}
+
+// First finds the first empty cell and returns a child candidate with it set
+// to 1, along with the index of the cell that was changed.
+func (n *Node) First() (Node, uint8) {
+	child := *n
 
 	var toChange uint8
 	for i, v := range n.Candidate {
@@ -128,23 +333,20 @@ func (n *Node) First() uint8 {
 
 	child.Candidate[toChange] = 1
 
-	n.Children[n.MostRecent] = &child
-
-	return toChange
+	return child, toChange
 }
 
-func (n *Node) Next(toChange uint8) bool {
-	if n.MostRecent >= 8 {
-		return false
+// Next advances prev's guess at cell toChange to the next digit. It reports
+// false once every digit 1..9 has been tried.
+func (n *Node) Next(prev Node, toChange uint8) (Node, bool) {
+	if prev.Candidate[toChange] >= 9 {
+		return Node{}, false
 	}
-	prev := n.Children[n.MostRecent]
-	child := copyCandidate(prev)
-	child.Candidate[toChange] += 1
 
-	n.MostRecent += 1
-	n.Children[n.MostRecent] = &child
+	child := prev
+	child.Candidate[toChange] += 1
 
-	return true
+	return child, true
 }
 
 func (n *Node) Accept() bool {
@@ -265,12 +467,3 @@ func parse(contents []byte) ([81]uint8, error) {
 		return puzzle, nil
 	}
 }
-
-func copyCandidate(n *Node) Node {
-	can := n.Candidate
-	return Node{
-		MostRecent: 0,
-		Children:   [9]*Node{nil},
-		Candidate:  can,
-	}
-}
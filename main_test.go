@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBacktrackParallelManyWorkers exercises BacktrackParallel with a
+// worker count above 9, the case where a sem-slot-holding dispatcher would
+// previously try to acquire further slots from the same bounded pool to
+// fan out its own children, starving the pool. It should solve quickly
+// instead of stalling down to near-sequential throughput.
+func TestBacktrackParallelManyWorkers(t *testing.T) {
+	puzzle := parsePuzzleForTest("083007060910002740207100000190000030652800070004000500501046007840709300729000006")
+
+	config := Config{Parallel: 16}
+	root := Node{Candidate: puzzle}
+
+	done := make(chan *Node, 1)
+	go func() {
+		done <- root.BacktrackParallel(&config)
+	}()
+
+	select {
+	case solution := <-done:
+		if solution == nil {
+			t.Fatal("BacktrackParallel found no solution")
+		}
+		if solution.Reject() {
+			t.Fatal("BacktrackParallel returned an invalid solution")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("BacktrackParallel with workers > 9 did not finish within 5s (worker pool starvation?)")
+	}
+}
+
+func parsePuzzleForTest(s string) [81]uint8 {
+	var grid [81]uint8
+	for i := 0; i < 81 && i < len(s); i++ {
+		grid[i] = s[i] - '0'
+	}
+	return grid
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// progressPrinter renders -print-partials output. Writing to a terminal
+// redraws the whole board in place using ANSI cursor-up escapes, for a
+// smooth animation. Anything else (a pipe, a file, or -plain-progress)
+// gets a throttled one-line summary instead, since re-dumping the whole
+// board on every step is unusable when piped.
+type progressPrinter struct {
+	out   io.Writer
+	tty   bool
+	every int
+
+	step       uint64
+	drawnLines int
+}
+
+// newProgressPrinter builds a printer for out. tty controls whether it
+// redraws in place; every throttles the plain summary to one line per
+// `every` steps (values <= 0 are treated as 1).
+func newProgressPrinter(out io.Writer, tty bool, every int) *progressPrinter {
+	if every <= 0 {
+		every = 1
+	}
+	return &progressPrinter{out: out, tty: tty, every: every}
+}
+
+// Print records one candidate assignment. cell is the index of the cell
+// that was just tried and digit the value tried; pass cell < 0 for the
+// initial, unmodified board. It is safe to call on a nil *progressPrinter.
+func (p *progressPrinter) Print(n *Node, cell int, digit uint8) {
+	if p == nil {
+		return
+	}
+
+	step := atomic.AddUint64(&p.step, 1)
+
+	if p.tty {
+		p.redraw(n)
+		return
+	}
+
+	if cell < 0 || step%uint64(p.every) != 0 {
+		return
+	}
+
+	fmt.Fprintf(p.out, "step=%d depth=%d cell=(%d,%d) tried=%d\n",
+		step, countFilled(n.Candidate), cell/9, cell%9, digit)
+}
+
+func (p *progressPrinter) redraw(n *Node) {
+	if p.drawnLines > 0 {
+		fmt.Fprintf(p.out, "\x1b[%dA", p.drawnLines)
+	}
+
+	var board bytes.Buffer
+	n.PrintSolution(&Config{Output: &board})
+	p.drawnLines = strings.Count(board.String(), "\n")
+	io.Copy(p.out, &board)
+}
+
+func countFilled(candidate [81]uint8) int {
+	n := 0
+	for _, v := range candidate {
+		if v != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// isTerminalWriter reports whether w is a character device such as an
+// interactive terminal.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestProgressPrinterPlainMode checks the non-tty summary line: one line
+// per step that has a non-negative cell, throttled to every `every` steps.
+func TestProgressPrinterPlainMode(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgressPrinter(&buf, false, 2)
+	n := &Node{}
+
+	p.Print(n, -1, 0) // step 1: initial board, never printed
+	p.Print(n, 0, 1)  // step 2: 2 % 2 == 0, printed
+	p.Print(n, 1, 2)  // step 3: 3 % 2 != 0, throttled
+	p.Print(n, 2, 3)  // step 4: printed
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "step=2") || !strings.Contains(lines[0], "cell=(0,0)") {
+		t.Errorf("line 0 = %q, want step=2 cell=(0,0)", lines[0])
+	}
+	if !strings.Contains(lines[1], "step=4") || !strings.Contains(lines[1], "cell=(0,2)") {
+		t.Errorf("line 1 = %q, want step=4 cell=(0,2)", lines[1])
+	}
+}
+
+// TestProgressPrinterPlainModeDefaultEvery checks that every <= 0 is
+// treated as 1, i.e. no throttling.
+func TestProgressPrinterPlainModeDefaultEvery(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgressPrinter(&buf, false, 0)
+	n := &Node{}
+
+	p.Print(n, 0, 1)
+	p.Print(n, 1, 2)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2 (no throttling): %q", len(lines), buf.String())
+	}
+}
+
+// TestProgressPrinterTTYMode checks that tty mode redraws the board on
+// every call, including the initial cell < 0 call, and that later redraws
+// emit a cursor-up escape sized to the previous frame's line count.
+func TestProgressPrinterTTYMode(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgressPrinter(&buf, true, 1)
+	n := &Node{}
+
+	p.Print(n, -1, 0)
+	first := buf.String()
+	if strings.Contains(first, "\x1b[") {
+		t.Errorf("first redraw should not cursor-up (nothing drawn yet): %q", first)
+	}
+	firstLines := strings.Count(first, "\n")
+	if firstLines == 0 {
+		t.Fatalf("first redraw printed no board: %q", first)
+	}
+
+	buf.Reset()
+	p.Print(n, 0, 1)
+	second := buf.String()
+	wantPrefix := "\x1b[" // cursor-up escape before the redrawn board
+	if !strings.HasPrefix(second, wantPrefix) {
+		t.Errorf("second redraw = %q, want a leading cursor-up escape", second)
+	}
+	if !strings.Contains(second, "["+strconv.Itoa(firstLines)+"A") {
+		t.Errorf("second redraw = %q, want cursor-up by %d lines", second, firstLines)
+	}
+}
+
+// TestCountFilled checks the helper used to report search depth in plain
+// progress output.
+func TestCountFilled(t *testing.T) {
+	var candidate [81]uint8
+	if got := countFilled(candidate); got != 0 {
+		t.Errorf("countFilled(empty) = %d, want 0", got)
+	}
+	candidate[0] = 1
+	candidate[80] = 9
+	if got := countFilled(candidate); got != 2 {
+		t.Errorf("countFilled = %d, want 2", got)
+	}
+}
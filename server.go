@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/BeaconBrigade/go-sudoku/dlx"
+)
+
+// serve starts an HTTP server exposing the solver as a small JSON/SSE
+// API: POST /solve, POST /validate, and GET /stream/solve. Concurrent
+// solves are capped at maxConcurrent so an adversarial puzzle can't tie
+// up every goroutine.
+func serve(addr string, solverName string, maxConcurrent int) error {
+	sem := make(chan struct{}, maxConcurrent)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/solve", func(w http.ResponseWriter, r *http.Request) {
+		handleSolve(w, r, solverName, sem)
+	})
+	mux.HandleFunc("/validate", handleValidate)
+	mux.HandleFunc("/stream/solve", func(w http.ResponseWriter, r *http.Request) {
+		handleStreamSolve(w, r, sem)
+	})
+
+	fmt.Println("Listening on", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+func handleSolve(w http.ResponseWriter, r *http.Request, solverName string, sem chan struct{}) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	candidate, err := decodePuzzle(r)
+	if err != nil {
+		http.Error(w, "could not parse puzzle: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := requestContext(r)
+	defer cancel()
+
+	select {
+	case sem <- struct{}{}:
+		defer func() { <-sem }()
+	case <-ctx.Done():
+		http.Error(w, "server busy", http.StatusServiceUnavailable)
+		return
+	}
+
+	grid, err := solveGrid(ctx, candidate, solverName)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, err.Error(), http.StatusGatewayTimeout)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	writeJSONGrid(w, grid)
+}
+
+func handleValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	candidate, err := decodePuzzle(r)
+	if err != nil {
+		http.Error(w, "could not parse puzzle: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(validate(candidate))
+}
+
+func handleStreamSolve(w http.ResponseWriter, r *http.Request, sem chan struct{}) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	candidate, err := decodePuzzle(r)
+	if err != nil {
+		http.Error(w, "could not parse puzzle: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := requestContext(r)
+	defer cancel()
+
+	select {
+	case sem <- struct{}{}:
+		defer func() { <-sem }()
+	case <-ctx.Done():
+		http.Error(w, "server busy", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	out := sseWriter{w: w, f: flusher}
+	config := Config{PrintPartials: true, Output: out, Progress: newProgressPrinter(out, false, 1)}
+	root := Node{Candidate: candidate}
+
+	solution := root.backtrack(ctx, &config, -1, 0)
+	if solution != nil {
+		fmt.Fprintf(w, "event: result\ndata: %s\n\n", gridString(solution.Candidate))
+	} else {
+		fmt.Fprint(w, "event: error\ndata: no solution\n\n")
+	}
+	flusher.Flush()
+}
+
+// defaultSolveTimeout bounds a solve when the client doesn't supply its own
+// `timeout` query parameter, so an adversarial puzzle can't park a solver
+// goroutine (and its semaphore slot) forever.
+const defaultSolveTimeout = 30 * time.Second
+
+// requestContext builds a context bound to the request's lifetime, honoring
+// an optional `timeout` query parameter (a Go duration string, e.g. "5s").
+// A missing or unparsable `timeout` falls back to defaultSolveTimeout rather
+// than running unbounded.
+func requestContext(r *http.Request) (context.Context, context.CancelFunc) {
+	if raw := r.URL.Query().Get("timeout"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			return context.WithTimeout(r.Context(), d)
+		}
+	}
+	return context.WithTimeout(r.Context(), defaultSolveTimeout)
+}
+
+// decodePuzzle reads a puzzle from the request body, negotiating on
+// Content-Type: application/json bodies carry {"puzzle": "..."}, anything
+// else is parsed as a plain 81-char string or the CLI's whitespace-tolerant
+// format.
+func decodePuzzle(r *http.Request) ([81]uint8, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return [81]uint8{}, err
+	}
+
+	if strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		var payload struct {
+			Puzzle string `json:"puzzle"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return [81]uint8{}, err
+		}
+		return parse([]byte(payload.Puzzle))
+	}
+
+	return parse(body)
+}
+
+func solveGrid(ctx context.Context, candidate [81]uint8, solverName string) ([81]uint8, error) {
+	switch solverName {
+	case "dlx":
+		return dlx.Solve(ctx, candidate)
+	case "backtrack", "":
+		root := Node{Candidate: candidate}
+		config := Config{Parallel: 1}
+		solution := root.backtrack(ctx, &config, -1, 0)
+		if solution == nil {
+			if err := ctx.Err(); err != nil {
+				return candidate, err
+			}
+			return candidate, errors.New("no solution")
+		}
+		return solution.Candidate, nil
+	default:
+		return candidate, fmt.Errorf("unknown solver: %s", solverName)
+	}
+}
+
+// Violations reports which rows, columns, and boxes contain a duplicate
+// non-zero digit.
+type Violations struct {
+	Rows  []int `json:"rows"`
+	Cols  []int `json:"cols"`
+	Boxes []int `json:"boxes"`
+}
+
+func validate(candidate [81]uint8) Violations {
+	var v Violations
+	var counter [10]int
+
+	for i := 0; i < 9; i++ {
+		if hasDuplicate(&counter, func(j int) uint8 { return candidate[i*9+j] }) {
+			v.Rows = append(v.Rows, i)
+		}
+	}
+
+	for i := 0; i < 9; i++ {
+		if hasDuplicate(&counter, func(j int) uint8 { return candidate[j*9+i] }) {
+			v.Cols = append(v.Cols, i)
+		}
+	}
+
+	for box := 0; box < 9; box++ {
+		offset := (box/3)*27 + (box%3)*3
+		if hasDuplicate(&counter, func(k int) uint8 {
+			return candidate[offset+(k/3)*9+k%3]
+		}) {
+			v.Boxes = append(v.Boxes, box)
+		}
+	}
+
+	return v
+}
+
+// hasDuplicate reports whether any digit 1..9 appears more than once among
+// at(0)..at(8), using counter as scratch space.
+func hasDuplicate(counter *[10]int, at func(i int) uint8) bool {
+	for i := range counter {
+		counter[i] = 0
+	}
+
+	dup := false
+	for i := 0; i < 9; i++ {
+		d := at(i)
+		if d == 0 {
+			continue
+		}
+		counter[d]++
+		if counter[d] > 1 {
+			dup = true
+		}
+	}
+	return dup
+}
+
+func gridString(candidate [81]uint8) string {
+	var b strings.Builder
+	for _, d := range candidate {
+		b.WriteByte('0' + d)
+	}
+	return b.String()
+}
+
+func writeJSONGrid(w http.ResponseWriter, grid [81]uint8) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"solution": gridString(grid)})
+}
+
+// sseWriter adapts an http.ResponseWriter into an io.Writer suitable for
+// Config.Output, wrapping each write as a Server-Sent Events "data:" frame.
+type sseWriter struct {
+	w http.ResponseWriter
+	f http.Flusher
+}
+
+func (s sseWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		fmt.Fprintf(s.w, "data: %s\n", line)
+	}
+	fmt.Fprint(s.w, "\n")
+	s.f.Flush()
+	return len(p), nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestHandleStreamSolveEmitsPartials checks that /stream/solve emits at
+// least one "data:" partial frame before its terminating "event: result",
+// i.e. that progress is actually wired up and not silently dropped by a
+// nil *progressPrinter.
+func TestHandleStreamSolveEmitsPartials(t *testing.T) {
+	// A puzzle with several blanks so the search takes more than one step.
+	puzzle := "083007060910002740207100000190000030652800070004000500501046007840709300729000000"
+
+	req := httptest.NewRequest("GET", "/stream/solve", strings.NewReader(puzzle))
+	rec := httptest.NewRecorder()
+
+	sem := make(chan struct{}, 1)
+	handleStreamSolve(rec, req, sem)
+
+	body := rec.Body.String()
+	resultIdx := strings.Index(body, "event: result")
+	if resultIdx == -1 {
+		t.Fatalf("response never emitted event: result; body=%q", body)
+	}
+
+	partialIdx := strings.Index(body, "data: ")
+	if partialIdx == -1 {
+		t.Fatalf("response had no partial data: frames; body=%q", body)
+	}
+	if partialIdx >= resultIdx {
+		t.Fatalf("first partial frame did not precede the result event; body=%q", body)
+	}
+}
+
+const solvablePuzzle = "083007060910002740207100000190000030652800070004000500501046007840709300729000006"
+
+// TestHandleSolvePlainBody checks the non-JSON content negotiation path:
+// a bare 81-char grid in the request body.
+func TestHandleSolvePlainBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/solve", strings.NewReader(solvablePuzzle))
+	rec := httptest.NewRecorder()
+
+	handleSolve(rec, req, "backtrack", make(chan struct{}, 1))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body=%q", rec.Code, rec.Body.String())
+	}
+
+	var payload struct {
+		Solution string `json:"solution"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+	if strings.Contains(payload.Solution, "0") {
+		t.Fatalf("solution %q still has blank cells", payload.Solution)
+	}
+}
+
+// TestHandleSolveJSONBody checks the application/json content negotiation
+// path: {"puzzle": "..."}.
+func TestHandleSolveJSONBody(t *testing.T) {
+	body := `{"puzzle": "` + solvablePuzzle + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/solve", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	handleSolve(rec, req, "backtrack", make(chan struct{}, 1))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body=%q", rec.Code, rec.Body.String())
+	}
+}
+
+// TestHandleSolveMalformedBody checks that a body that can't be parsed as a
+// puzzle is rejected with 400, not passed through to the solver.
+func TestHandleSolveMalformedBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/solve", strings.NewReader("not a puzzle"))
+	rec := httptest.NewRecorder()
+
+	handleSolve(rec, req, "backtrack", make(chan struct{}, 1))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d; body=%q", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+// TestHandleSolveMethodNotAllowed checks that only POST is accepted.
+func TestHandleSolveMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/solve", nil)
+	rec := httptest.NewRecorder()
+
+	handleSolve(rec, req, "backtrack", make(chan struct{}, 1))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+// TestHandleSolveTimeout checks that a `timeout` query parameter that
+// expires mid-solve surfaces as 504, not the generic 422 used for an
+// unsolvable puzzle. The puzzle is a deliberately pathological one so the
+// backtracker is still running when the 1ms deadline hits.
+func TestHandleSolveTimeout(t *testing.T) {
+	pathological := "800000000003600000070090200050007000000045700000100030001000068008500010090000400"
+	req := httptest.NewRequest(http.MethodPost, "/solve?timeout=1ms", strings.NewReader(pathological))
+	rec := httptest.NewRecorder()
+
+	handleSolve(rec, req, "backtrack", make(chan struct{}, 1))
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("got status %d, want %d; body=%q", rec.Code, http.StatusGatewayTimeout, rec.Body.String())
+	}
+}
+
+// TestHandleValidate checks both a clean grid and one with a duplicate.
+func TestHandleValidate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(solvablePuzzle))
+	rec := httptest.NewRecorder()
+
+	handleValidate(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body=%q", rec.Code, rec.Body.String())
+	}
+	var v Violations
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+	if len(v.Rows) != 0 || len(v.Cols) != 0 || len(v.Boxes) != 0 {
+		t.Fatalf("clean puzzle reported violations: %+v", v)
+	}
+
+	// Force a row conflict: two 1s in row 0.
+	dup := "11" + solvablePuzzle[2:]
+	req = httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(dup))
+	rec = httptest.NewRecorder()
+
+	handleValidate(rec, req)
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+	if len(v.Rows) == 0 {
+		t.Fatalf("duplicate digit in row 0 not reported: %+v", v)
+	}
+}
+
+// TestHandleValidateMethodNotAllowed checks that only POST is accepted.
+func TestHandleValidateMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/validate", nil)
+	rec := httptest.NewRecorder()
+
+	handleValidate(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,152 @@
+// Package trace records a timeline of solver events (candidate
+// assignment, rejection, backtrack, accept) as JSON lines, and computes
+// summary statistics from a recorded trace.
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event names one kind of solver step.
+type Event string
+
+const (
+	EventAssign    Event = "assign"
+	EventReject    Event = "reject"
+	EventBacktrack Event = "backtrack"
+	EventAccept    Event = "accept"
+)
+
+// Record is a single timeline entry.
+type Record struct {
+	TNs   int64 `json:"t_ns"`
+	Cell  int   `json:"cell_index"`
+	Digit uint8 `json:"digit"`
+	Event Event `json:"event"`
+}
+
+// Writer appends Records to an underlying io.Writer as JSON lines. It is
+// safe for concurrent use, since the parallel backtracking solver may
+// record events from multiple goroutines at once.
+//
+// A nil *Writer is valid and every method on it is a no-op, so tracing
+// can be threaded through the solver's hot path unconditionally: callers
+// just do `config.Trace.Record(...)` and pay nothing when tracing is
+// disabled.
+type Writer struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	start time.Time
+}
+
+// New wraps w so solver events can be recorded to it.
+func New(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w), start: time.Now()}
+}
+
+// Record appends one event to the trace. It is safe to call on a nil
+// *Writer.
+func (t *Writer) Record(event Event, cell int, digit uint8) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// Encoding errors here would mean the trace file is no longer
+	// writable; there's nothing useful to do but drop the record.
+	_ = t.enc.Encode(Record{
+		TNs:   time.Since(t.start).Nanoseconds(),
+		Cell:  cell,
+		Digit: digit,
+		Event: event,
+	})
+}
+
+// Stats summarizes a recorded trace.
+type Stats struct {
+	Nodes        int
+	Backtracks   int
+	Accepts      int
+	AvgBranching float64
+	// TimePerDepth maps search depth to total nanoseconds spent with
+	// that depth as the active frame.
+	TimePerDepth map[int]int64
+	// Utilization is the fraction of events, sampled over fixed-size
+	// windows, that represented forward progress (assign/accept) rather
+	// than backtracking.
+	Utilization []float64
+}
+
+// sampleWindow is how many records make up one utilization sample.
+const sampleWindow = 1000
+
+// Analyze reads a JSON-lines trace produced by Writer and computes
+// summary statistics: nodes explored, average branching factor,
+// time-per-depth, and a solver utilization curve.
+func Analyze(r io.Reader) (Stats, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	stats := Stats{TimePerDepth: map[int]int64{}}
+	var stack []int
+	var lastT int64
+	windowForward, windowTotal := 0, 0
+
+	for {
+		var rec Record
+		if err := dec.Decode(&rec); err == io.EOF {
+			break
+		} else if err != nil {
+			return stats, err
+		}
+
+		depth := len(stack) - 1
+		if depth < 0 {
+			depth = 0
+		}
+		if lastT != 0 {
+			stats.TimePerDepth[depth] += rec.TNs - lastT
+		}
+		lastT = rec.TNs
+
+		switch rec.Event {
+		case EventAssign:
+			if len(stack) == 0 || stack[len(stack)-1] != rec.Cell {
+				stack = append(stack, rec.Cell)
+			}
+			stats.Nodes++
+			windowForward++
+		case EventAccept:
+			stats.Accepts++
+			windowForward++
+		case EventBacktrack:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			stats.Backtracks++
+		case EventReject:
+			// Rejections don't change depth or forward-progress
+			// accounting; they're accounted for via the following
+			// backtrack.
+		}
+
+		windowTotal++
+		if windowTotal >= sampleWindow {
+			stats.Utilization = append(stats.Utilization, float64(windowForward)/float64(windowTotal))
+			windowForward, windowTotal = 0, 0
+		}
+	}
+
+	if windowTotal > 0 {
+		stats.Utilization = append(stats.Utilization, float64(windowForward)/float64(windowTotal))
+	}
+
+	if stats.Backtracks+stats.Accepts > 0 {
+		stats.AvgBranching = float64(stats.Nodes) / float64(stats.Backtracks+stats.Accepts)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,88 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// writeTrace encodes recs as JSON lines, the format Writer produces.
+func writeTrace(t *testing.T, recs []Record) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range recs {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("could not encode record: %v", err)
+		}
+	}
+	return &buf
+}
+
+// TestAnalyze feeds Analyze a small synthetic trace with a hand-computed
+// expected Stats: assign cell 0, reject, backtrack it, assign cell 0 again
+// to a different digit, assign a deeper cell 1, then accept.
+func TestAnalyze(t *testing.T) {
+	recs := []Record{
+		{TNs: 10, Cell: 0, Digit: 1, Event: EventAssign},
+		{TNs: 20, Cell: 0, Digit: 1, Event: EventReject},
+		{TNs: 30, Cell: 0, Digit: 1, Event: EventBacktrack},
+		{TNs: 40, Cell: 0, Digit: 2, Event: EventAssign},
+		{TNs: 50, Cell: 1, Digit: 5, Event: EventAssign},
+		{TNs: 60, Cell: 1, Digit: 5, Event: EventAccept},
+	}
+
+	stats, err := Analyze(writeTrace(t, recs))
+	if err != nil {
+		t.Fatalf("Analyze returned an error: %v", err)
+	}
+
+	if stats.Nodes != 3 {
+		t.Errorf("Nodes = %d, want 3", stats.Nodes)
+	}
+	if stats.Backtracks != 1 {
+		t.Errorf("Backtracks = %d, want 1", stats.Backtracks)
+	}
+	if stats.Accepts != 1 {
+		t.Errorf("Accepts = %d, want 1", stats.Accepts)
+	}
+	if want := 1.5; stats.AvgBranching != want {
+		t.Errorf("AvgBranching = %v, want %v", stats.AvgBranching, want)
+	}
+
+	wantTimePerDepth := map[int]int64{0: 40, 1: 10}
+	if len(stats.TimePerDepth) != len(wantTimePerDepth) {
+		t.Fatalf("TimePerDepth = %v, want %v", stats.TimePerDepth, wantTimePerDepth)
+	}
+	for depth, want := range wantTimePerDepth {
+		if got := stats.TimePerDepth[depth]; got != want {
+			t.Errorf("TimePerDepth[%d] = %d, want %d", depth, got, want)
+		}
+	}
+
+	if len(stats.Utilization) != 1 {
+		t.Fatalf("Utilization = %v, want a single window", stats.Utilization)
+	}
+	if want := 4.0 / 6.0; math.Abs(stats.Utilization[0]-want) > 1e-9 {
+		t.Errorf("Utilization[0] = %v, want %v", stats.Utilization[0], want)
+	}
+}
+
+// TestAnalyzeEmpty checks that an empty trace produces zeroed stats rather
+// than a division-by-zero or a nil-map panic.
+func TestAnalyzeEmpty(t *testing.T) {
+	stats, err := Analyze(writeTrace(t, nil))
+	if err != nil {
+		t.Fatalf("Analyze returned an error: %v", err)
+	}
+	if stats.Nodes != 0 || stats.Backtracks != 0 || stats.Accepts != 0 {
+		t.Errorf("non-zero stats from an empty trace: %+v", stats)
+	}
+	if stats.AvgBranching != 0 {
+		t.Errorf("AvgBranching = %v, want 0", stats.AvgBranching)
+	}
+	if len(stats.Utilization) != 0 {
+		t.Errorf("Utilization = %v, want none", stats.Utilization)
+	}
+}